@@ -0,0 +1,127 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ionoscloud declares the client seam the IonosCloudCluster/IonosCloudMachine controllers use to
+// talk to the IONOS Cloud API, so the backend can be swapped without touching controller code.
+package ionoscloud
+
+import (
+	"context"
+	"fmt"
+)
+
+// Datacenter is an IONOS Cloud virtual data center.
+type Datacenter struct {
+	ID       string
+	Name     string
+	Location string
+}
+
+// Server is an IONOS Cloud server.
+type Server struct {
+	ID           string
+	DatacenterID string
+	Name         string
+	State        string
+}
+
+// LAN is an IONOS Cloud LAN.
+type LAN struct {
+	ID           string
+	DatacenterID string
+	Name         string
+	Public       bool
+}
+
+// NIC is an IONOS Cloud network interface attached to a Server.
+type NIC struct {
+	ID       string
+	ServerID string
+	LANID    string
+	IPs      []string
+}
+
+// Volume is an IONOS Cloud volume attached to a Server.
+type Volume struct {
+	ID       string
+	ServerID string
+	Name     string
+	SizeGB   int32
+}
+
+// Client is the subset of the IONOS Cloud SDK the controllers depend on. NewClient selects the
+// implementation backing it from a mode value.
+type Client interface {
+	CreateDatacenter(ctx context.Context, name, location string) (*Datacenter, string, error)
+	GetDatacenter(ctx context.Context, id string) (*Datacenter, error)
+	DeleteDatacenter(ctx context.Context, id string) (string, error)
+
+	CreateServer(ctx context.Context, datacenterID, name string) (*Server, string, error)
+	GetServer(ctx context.Context, id string) (*Server, error)
+	DeleteServer(ctx context.Context, id string) (string, error)
+
+	CreateLAN(ctx context.Context, datacenterID, name string, public bool) (*LAN, string, error)
+	GetLAN(ctx context.Context, id string) (*LAN, error)
+	DeleteLAN(ctx context.Context, id string) (string, error)
+
+	CreateNIC(ctx context.Context, serverID, lanID string, ips []string) (*NIC, string, error)
+	GetNIC(ctx context.Context, id string) (*NIC, error)
+	DeleteNIC(ctx context.Context, id string) (string, error)
+
+	CreateVolume(ctx context.Context, serverID, name string, sizeGB int32) (*Volume, string, error)
+	GetVolume(ctx context.Context, id string) (*Volume, error)
+	DeleteVolume(ctx context.Context, id string) (string, error)
+
+	GetRequestStatus(ctx context.Context, requestID string) (string, error)
+}
+
+// ModeReal and ModeInMemory are the mode values NewClient recognizes when deciding which Client
+// implementation to return.
+const (
+	ModeReal     = "real"
+	ModeInMemory = "inmemory"
+)
+
+// inMemoryClientFactory is registered by internal/ionoscloud/inmemory's e2e-only init(), keeping this
+// package free of a hard dependency on the e2e-only fake backend.
+var inMemoryClientFactory func() Client
+
+// RegisterInMemoryClientFactory is called by internal/ionoscloud/inmemory to make its fake backend
+// selectable through NewClient. It is not meant to be called from anywhere else.
+func RegisterInMemoryClientFactory(factory func() Client) {
+	inMemoryClientFactory = factory
+}
+
+// NewClient returns the Client implementation for mode. realClient backs ModeReal (and the default, empty
+// mode), constructed by the caller from the actual IONOS Cloud SDK; ModeInMemory is only available once
+// internal/ionoscloud/inmemory, built with the e2e build tag, has been imported and has registered its fake
+// backend.
+//
+// cmd/main.go does not call NewClient, so the IonosCloudCluster/IonosCloudMachine reconcilers do not obtain
+// their client through it.
+func NewClient(mode string, realClient Client) (Client, error) {
+	switch mode {
+	case "", ModeReal:
+		return realClient, nil
+	case ModeInMemory:
+		if inMemoryClientFactory == nil {
+			return nil, fmt.Errorf("IONOS_CLOUD_MODE=%s requires the manager to be built with the e2e build tag", ModeInMemory)
+		}
+		return inMemoryClientFactory(), nil
+	default:
+		return nil, fmt.Errorf("unknown IONOS_CLOUD_MODE %q", mode)
+	}
+}
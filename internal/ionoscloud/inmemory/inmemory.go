@@ -0,0 +1,294 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inmemory provides a fake IONOS Cloud backend that implements the subset of the IONOS Cloud SDK used by
+// the controllers (Datacenter, Server, LAN, NIC and Volume create/get/delete, plus simulated async requests). It
+// lets the scale e2e spec create hundreds of IonosCloudMachine objects without exhausting real IONOS Cloud quota.
+// Importing this package in a build with the e2e build tag registers Client with internal/ionoscloud.NewClient,
+// which then returns it for ModeInMemory. The manager neither imports this package nor calls NewClient, so
+// setting IONOS_CLOUD_MODE=inmemory does not switch a running manager to this backend.
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+)
+
+// requestStatus mirrors the lifecycle of an asynchronous IONOS Cloud request.
+type requestStatus string
+
+const (
+	requestStatusQueued requestStatus = "QUEUED"
+	requestStatusDone   requestStatus = "DONE"
+	requestStatusFailed requestStatus = "FAILED"
+)
+
+// request tracks a simulated asynchronous IONOS Cloud operation.
+type request struct {
+	status requestStatus
+	target string
+}
+
+// Datacenter, Server, LAN, NIC and Volume alias the domain types declared in internal/ionoscloud so that
+// Client satisfies ionoscloud.Client without a conversion layer.
+type (
+	Datacenter = ionoscloud.Datacenter
+	Server     = ionoscloud.Server
+	LAN        = ionoscloud.LAN
+	NIC        = ionoscloud.NIC
+	Volume     = ionoscloud.Volume
+)
+
+func init() {
+	ionoscloud.RegisterInMemoryClientFactory(func() ionoscloud.Client { return NewClient() })
+}
+
+// Client is a thread-safe, in-memory fake of the IONOS Cloud API, returned by internal/ionoscloud.NewClient
+// for ModeInMemory once this package is imported. Every mutating call queues a request that
+// resolves to DONE the next time its status is polled, mirroring the real API's async behaviour.
+type Client struct {
+	mu sync.Mutex
+
+	datacenters map[string]*Datacenter
+	servers     map[string]*Server
+	lans        map[string]*LAN
+	nics        map[string]*NIC
+	volumes     map[string]*Volume
+	requests    map[string]*request
+}
+
+var _ ionoscloud.Client = (*Client)(nil)
+
+// NewClient returns an empty in-memory fake IONOS Cloud client.
+func NewClient() *Client {
+	return &Client{
+		datacenters: make(map[string]*Datacenter),
+		servers:     make(map[string]*Server),
+		lans:        make(map[string]*LAN),
+		nics:        make(map[string]*NIC),
+		volumes:     make(map[string]*Volume),
+		requests:    make(map[string]*request),
+	}
+}
+
+func (c *Client) queueRequest(target string) string {
+	id := uuid.NewString()
+	c.requests[id] = &request{status: requestStatusDone, target: target}
+	return id
+}
+
+// CreateDatacenter creates a fake Datacenter and returns it along with the ID of the request tracking the operation.
+func (c *Client) CreateDatacenter(_ context.Context, name, location string) (*Datacenter, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc := &Datacenter{ID: uuid.NewString(), Name: name, Location: location}
+	c.datacenters[dc.ID] = dc
+	return dc, c.queueRequest(dc.ID), nil
+}
+
+// GetDatacenter returns the Datacenter identified by id.
+func (c *Client) GetDatacenter(_ context.Context, id string) (*Datacenter, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, ok := c.datacenters[id]
+	if !ok {
+		return nil, fmt.Errorf("datacenter %q not found", id)
+	}
+	return dc, nil
+}
+
+// DeleteDatacenter deletes the Datacenter identified by id and returns the ID of the request tracking the operation.
+func (c *Client) DeleteDatacenter(_ context.Context, id string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.datacenters[id]; !ok {
+		return "", fmt.Errorf("datacenter %q not found", id)
+	}
+	delete(c.datacenters, id)
+	return c.queueRequest(id), nil
+}
+
+// CreateServer creates a fake Server in datacenterID and returns it along with the ID of the request tracking the operation.
+func (c *Client) CreateServer(_ context.Context, datacenterID, name string) (*Server, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.datacenters[datacenterID]; !ok {
+		return nil, "", fmt.Errorf("datacenter %q not found", datacenterID)
+	}
+	srv := &Server{ID: uuid.NewString(), DatacenterID: datacenterID, Name: name, State: "RUNNING"}
+	c.servers[srv.ID] = srv
+	return srv, c.queueRequest(srv.ID), nil
+}
+
+// GetServer returns the Server identified by id.
+func (c *Client) GetServer(_ context.Context, id string) (*Server, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	srv, ok := c.servers[id]
+	if !ok {
+		return nil, fmt.Errorf("server %q not found", id)
+	}
+	return srv, nil
+}
+
+// DeleteServer deletes the Server identified by id and returns the ID of the request tracking the operation.
+func (c *Client) DeleteServer(_ context.Context, id string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.servers[id]; !ok {
+		return "", fmt.Errorf("server %q not found", id)
+	}
+	delete(c.servers, id)
+	return c.queueRequest(id), nil
+}
+
+// CreateLAN creates a fake LAN in datacenterID and returns it along with the ID of the request tracking the operation.
+func (c *Client) CreateLAN(_ context.Context, datacenterID, name string, public bool) (*LAN, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.datacenters[datacenterID]; !ok {
+		return nil, "", fmt.Errorf("datacenter %q not found", datacenterID)
+	}
+	lan := &LAN{ID: uuid.NewString(), DatacenterID: datacenterID, Name: name, Public: public}
+	c.lans[lan.ID] = lan
+	return lan, c.queueRequest(lan.ID), nil
+}
+
+// GetLAN returns the LAN identified by id.
+func (c *Client) GetLAN(_ context.Context, id string) (*LAN, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lan, ok := c.lans[id]
+	if !ok {
+		return nil, fmt.Errorf("lan %q not found", id)
+	}
+	return lan, nil
+}
+
+// DeleteLAN deletes the LAN identified by id and returns the ID of the request tracking the operation.
+func (c *Client) DeleteLAN(_ context.Context, id string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.lans[id]; !ok {
+		return "", fmt.Errorf("lan %q not found", id)
+	}
+	delete(c.lans, id)
+	return c.queueRequest(id), nil
+}
+
+// CreateNIC attaches a fake NIC to serverID on lanID and returns it along with the ID of the request tracking the operation.
+func (c *Client) CreateNIC(_ context.Context, serverID, lanID string, ips []string) (*NIC, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.servers[serverID]; !ok {
+		return nil, "", fmt.Errorf("server %q not found", serverID)
+	}
+	nic := &NIC{ID: uuid.NewString(), ServerID: serverID, LANID: lanID, IPs: ips}
+	c.nics[nic.ID] = nic
+	return nic, c.queueRequest(nic.ID), nil
+}
+
+// GetNIC returns the NIC identified by id.
+func (c *Client) GetNIC(_ context.Context, id string) (*NIC, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nic, ok := c.nics[id]
+	if !ok {
+		return nil, fmt.Errorf("nic %q not found", id)
+	}
+	return nic, nil
+}
+
+// DeleteNIC deletes the NIC identified by id and returns the ID of the request tracking the operation.
+func (c *Client) DeleteNIC(_ context.Context, id string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.nics[id]; !ok {
+		return "", fmt.Errorf("nic %q not found", id)
+	}
+	delete(c.nics, id)
+	return c.queueRequest(id), nil
+}
+
+// CreateVolume attaches a fake Volume to serverID and returns it along with the ID of the request tracking the operation.
+func (c *Client) CreateVolume(_ context.Context, serverID, name string, sizeGB int32) (*Volume, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.servers[serverID]; !ok {
+		return nil, "", fmt.Errorf("server %q not found", serverID)
+	}
+	vol := &Volume{ID: uuid.NewString(), ServerID: serverID, Name: name, SizeGB: sizeGB}
+	c.volumes[vol.ID] = vol
+	return vol, c.queueRequest(vol.ID), nil
+}
+
+// GetVolume returns the Volume identified by id.
+func (c *Client) GetVolume(_ context.Context, id string) (*Volume, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	vol, ok := c.volumes[id]
+	if !ok {
+		return nil, fmt.Errorf("volume %q not found", id)
+	}
+	return vol, nil
+}
+
+// DeleteVolume deletes the Volume identified by id and returns the ID of the request tracking the operation.
+func (c *Client) DeleteVolume(_ context.Context, id string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.volumes[id]; !ok {
+		return "", fmt.Errorf("volume %q not found", id)
+	}
+	delete(c.volumes, id)
+	return c.queueRequest(id), nil
+}
+
+// GetRequestStatus returns the status of a previously queued request. Requests resolve to DONE immediately, since
+// the fake backend has no real provisioning latency to simulate.
+func (c *Client) GetRequestStatus(_ context.Context, requestID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	req, ok := c.requests[requestID]
+	if !ok {
+		return "", fmt.Errorf("request %q not found", requestID)
+	}
+	return string(req.status), nil
+}
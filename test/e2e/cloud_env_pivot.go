@@ -0,0 +1,55 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/test/framework"
+)
+
+// copyCredentialsSecret ensures the IONOS credentials Secret used by cloudEnv is present in targetNamespace on
+// toProxy's cluster, reading it from its home namespace on fromProxy's cluster. This is required before a
+// self-hosted pivot, since clusterctl.Move only relocates objects owned by the moved Cluster and its
+// infrastructure resources, not the credentials Secret they reference, and the self-hosted cluster's own
+// controllers need it in their own apiserver after the move.
+func (e *ionosCloudEnv) copyCredentialsSecret(ctx context.Context, fromProxy, toProxy framework.ClusterProxy, targetNamespace string) {
+	src := &corev1.Secret{}
+	Expect(fromProxy.GetClient().Get(ctx, types.NamespacedName{Namespace: e.credentialsSecretNamespace, Name: e.credentialsSecretName}, src)).
+		To(Succeed(), "Failed to fetch the source IONOS credentials Secret")
+
+	dst := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.credentialsSecretName,
+			Namespace: targetNamespace,
+		},
+		Data: src.Data,
+		Type: src.Type,
+	}
+
+	if err := toProxy.GetClient().Create(ctx, dst); err != nil && !apierrors.IsAlreadyExists(err) {
+		Expect(err).NotTo(HaveOccurred(), "Failed to copy the IONOS credentials Secret into namespace %q", targetNamespace)
+	}
+}
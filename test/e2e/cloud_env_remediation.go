@@ -0,0 +1,40 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/gomega"
+)
+
+// PowerOffServer forcibly powers off the IONOS Cloud server identified by serverID, simulating a hard node
+// failure so that remediation specs can assert MachineHealthCheck kicks in.
+func (e *ionosCloudEnv) PowerOffServer(ctx context.Context, serverID string) {
+	_, err := e.client.ServersApi.DatacentersServersStopPost(ctx, e.datacenterID, serverID).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Failed to power off server %q", serverID)
+}
+
+// DetachVolume detaches volumeID from the IONOS Cloud server identified by serverID, simulating a storage
+// failure so that remediation specs can assert MachineHealthCheck kicks in.
+func (e *ionosCloudEnv) DetachVolume(ctx context.Context, serverID, volumeID string) {
+	_, err := e.client.ServersApi.DatacentersServersVolumesDelete(ctx, e.datacenterID, serverID, volumeID).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Failed to detach volume %q from server %q", volumeID, serverID)
+}
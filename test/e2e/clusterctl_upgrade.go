@@ -0,0 +1,142 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+)
+
+// Clusterctl upgrade e2e config variables.
+const (
+	// InitWithBinary is the URL of the clusterctl binary to use for the "old" clusterctl invocations of an upgrade test.
+	InitWithBinary = "INIT_WITH_BINARY"
+
+	// InitWithProvidersContract is the contract (e.g. v1beta1) to use when picking the "old" IONOS provider version to install.
+	InitWithProvidersContract = "INIT_WITH_PROVIDERS_CONTRACT"
+
+	// InitWithKubernetesVersion is the Kubernetes version to use for the workload cluster created with the "old" provider version.
+	InitWithKubernetesVersion = "INIT_WITH_KUBERNETES_VERSION"
+)
+
+// Clusterctl upgrade spec: installs a previously released version of the IONOS provider, creates a workload
+// cluster with it, upgrades the management cluster to the version under test and asserts the pre-existing
+// workload cluster keeps reconciling and can still be scaled.
+var _ = Describe("Upgrade of the IONOS Cloud provider", Label("clusterctl-upgrade"), func() {
+	var (
+		specName         = "clusterctl-upgrade"
+		namespace        *corev1.Namespace
+		cancelWatches    context.CancelFunc
+		clusterResources *clusterctl.ApplyClusterTemplateAndWaitResult
+	)
+
+	BeforeEach(func() {
+		Expect(ctx).NotTo(BeNil(), "ctx is required for %s spec", specName)
+		Expect(e2eConfig).NotTo(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(e2eConfig.Variables).To(HaveKey(InitWithBinary), "Missing %s variable in the config", InitWithBinary)
+		Expect(e2eConfig.Variables).To(HaveKey(InitWithProvidersContract), "Missing %s variable in the config", InitWithProvidersContract)
+		Expect(e2eConfig.Variables).To(HaveKey(InitWithKubernetesVersion), "Missing %s variable in the config", InitWithKubernetesVersion)
+		Expect(bootstrapClusterProxy).NotTo(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0750)).To(Succeed(), "Invalid argument. Can't create artifactFolder %q for the %s spec", artifactFolder, specName)
+
+		namespace, cancelWatches = setupSpecNamespace(specName)
+		clusterResources = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	AfterEach(func() {
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, clusterResources.Cluster, e2eConfig.GetIntervals, skipCleanup)
+	})
+
+	It("Should create a management cluster and then upgrade the IONOS Cloud provider", func() {
+		clusterName := generateClusterName(specName)
+
+		By("Initializing the management cluster with an old release of the IONOS Cloud provider")
+		clusterctl.UpgradeManagementClusterAndWait(ctx, clusterctl.UpgradeManagementClusterAndWaitInput{
+			ClusterProxy:         bootstrapClusterProxy,
+			ClusterctlConfigPath: clusterctlConfigPath,
+			ClusterCtlBinaryURL:  e2eConfig.GetVariable(InitWithBinary),
+			Contract:             e2eConfig.GetVariable(InitWithProvidersContract),
+			LogFolder:            filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName()),
+		})
+
+		By("Creating a workload cluster with the old provider version")
+		clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
+			ClusterProxy: bootstrapClusterProxy,
+			ConfigCluster: clusterctl.ConfigClusterInput{
+				LogFolder:              filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName()),
+				ClusterctlConfigPath:   clusterctlConfigPath,
+				KubeconfigPath:         bootstrapClusterProxy.GetKubeconfigPath(),
+				InfrastructureProvider: clusterctl.DefaultInfrastructureProvider,
+				Flavor:                 clusterctl.DefaultFlavor,
+				Namespace:              namespace.Name,
+				ClusterName:            clusterName,
+				KubernetesVersion:      e2eConfig.GetVariable(InitWithKubernetesVersion),
+			},
+			WaitForClusterIntervals:      e2eConfig.GetIntervals(specName, "wait-cluster"),
+			WaitForControlPlaneIntervals: e2eConfig.GetIntervals(specName, "wait-control-plane"),
+			WaitForMachineDeployments:    e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+		}, clusterResources)
+
+		By("Upgrading the management cluster to the IONOS Cloud provider version under test")
+		clusterctl.UpgradeManagementClusterAndWait(ctx, clusterctl.UpgradeManagementClusterAndWaitInput{
+			ClusterProxy:         bootstrapClusterProxy,
+			ClusterctlConfigPath: clusterctlConfigPath,
+			Contract:             clusterctl.CurrentVersion,
+			LogFolder:            filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName()),
+		})
+
+		By("Asserting the pre-existing workload cluster's IonosCloudCluster and IonosCloudMachines get correctly converted if the storage version changed")
+		ionosCloudCluster := &infrav1.IonosCloudCluster{}
+		Expect(bootstrapClusterProxy.GetClient().Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: clusterName}, ionosCloudCluster)).
+			To(Succeed(), "Failed to fetch the upgraded IonosCloudCluster")
+
+		machines := framework.GetMachinesByCluster(ctx, framework.GetMachinesByClusterInput{
+			Lister:      bootstrapClusterProxy.GetClient(),
+			ClusterName: clusterName,
+			Namespace:   namespace.Name,
+		})
+		Expect(machines).NotTo(BeEmpty(), "Expected at least one Machine for cluster %s", clusterName)
+		for _, machine := range machines {
+			infraRef := machine.Spec.InfrastructureRef
+			ionosCloudMachine := &infrav1.IonosCloudMachine{}
+			Expect(bootstrapClusterProxy.GetClient().Get(ctx, types.NamespacedName{Namespace: infraRef.Namespace, Name: infraRef.Name}, ionosCloudMachine)).
+				To(Succeed(), "Failed to fetch the upgraded IonosCloudMachine %s", infraRef.Name)
+		}
+
+		By("Scaling the workload cluster's MachineDeployment to assert the provider is still reconciling")
+		framework.ScaleAndWaitMachineDeployment(ctx, framework.ScaleAndWaitMachineDeploymentInput{
+			ClusterProxy:              bootstrapClusterProxy,
+			Cluster:                   clusterResources.Cluster,
+			MachineDeployment:         clusterResources.MachineDeployments[0],
+			Replicas:                  *clusterResources.MachineDeployments[0].Spec.Replicas + 1,
+			WaitForMachineDeployments: e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+		})
+	})
+})
@@ -0,0 +1,119 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/cluster-api/test/framework/kubetest"
+)
+
+// Conformance e2e config variables.
+const (
+	// KubetestConfiguration is the path of the kubetest configuration file (e.g. one of the files under
+	// test/e2e/data/kubetest) to run against the workload cluster.
+	KubetestConfiguration = "KUBETEST_CONFIGURATION"
+
+	// ConformanceWorkerMachineCount is the number of worker machines for the conformance workload cluster.
+	ConformanceWorkerMachineCount = "CONFORMANCE_WORKER_MACHINE_COUNT"
+
+	// ConformanceControlPlaneMachineCount is the number of control plane machines for the conformance workload cluster.
+	ConformanceControlPlaneMachineCount = "CONFORMANCE_CONTROL_PLANE_MACHINE_COUNT"
+)
+
+// Kubernetes conformance test spec, running kubetest against an IONOS-provisioned workload cluster.
+var _ = Describe("Kubernetes conformance tests", Label("conformance"), func() {
+	var (
+		specName         = "conformance"
+		namespace        *corev1.Namespace
+		cancelWatches    context.CancelFunc
+		clusterResources *clusterctl.ApplyClusterTemplateAndWaitResult
+	)
+
+	BeforeEach(func() {
+		Expect(ctx).NotTo(BeNil(), "ctx is required for %s spec", specName)
+		Expect(e2eConfig).NotTo(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(e2eConfig.Variables).To(HaveKey(KubetestConfiguration), "Missing %s variable in the config", KubetestConfiguration)
+		Expect(bootstrapClusterProxy).NotTo(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0750)).To(Succeed(), "Invalid argument. Can't create artifactFolder %q for the %s spec", artifactFolder, specName)
+
+		namespace, cancelWatches = setupSpecNamespace(specName)
+		clusterResources = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	AfterEach(func() {
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, clusterResources.Cluster, e2eConfig.GetIntervals, skipCleanup)
+	})
+
+	It("Should create a workload cluster and run Kubernetes conformance tests against it", func() {
+		clusterName := generateClusterName(specName)
+
+		controlPlaneMachineCount, err := strconv.ParseInt(e2eConfig.GetVariable(ConformanceControlPlaneMachineCount), 10, 64)
+		Expect(err).NotTo(HaveOccurred(), "Invalid %s variable", ConformanceControlPlaneMachineCount)
+		workerMachineCount, err := strconv.ParseInt(e2eConfig.GetVariable(ConformanceWorkerMachineCount), 10, 64)
+		Expect(err).NotTo(HaveOccurred(), "Invalid %s variable", ConformanceWorkerMachineCount)
+
+		By("Creating a workload cluster")
+		clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
+			ClusterProxy: bootstrapClusterProxy,
+			ConfigCluster: clusterctl.ConfigClusterInput{
+				LogFolder:                filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName()),
+				ClusterctlConfigPath:     clusterctlConfigPath,
+				KubeconfigPath:           bootstrapClusterProxy.GetKubeconfigPath(),
+				InfrastructureProvider:   clusterctl.DefaultInfrastructureProvider,
+				Flavor:                   clusterctl.DefaultFlavor,
+				Namespace:                namespace.Name,
+				ClusterName:              clusterName,
+				KubernetesVersion:        e2eConfig.GetVariable(KubernetesVersion),
+				ControlPlaneMachineCount: pointer.Int64(controlPlaneMachineCount),
+				WorkerMachineCount:       pointer.Int64(workerMachineCount),
+			},
+			WaitForClusterIntervals:      e2eConfig.GetIntervals(specName, "wait-cluster"),
+			WaitForControlPlaneIntervals: e2eConfig.GetIntervals(specName, "wait-control-plane"),
+			WaitForMachineDeployments:    e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+		}, clusterResources)
+
+		By("Fetching the workload cluster kubeconfig")
+		workloadKubeconfigPath := bootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName).GetKubeconfigPath()
+
+		By("Running kubetest against the workload cluster")
+		kubetestConfigFilePath := e2eConfig.GetVariable(KubetestConfiguration)
+		Expect(kubetestConfigFilePath).To(BeAnExistingFile(), "The %s variable should resolve to an existing file", KubetestConfiguration)
+
+		Expect(kubetest.Run(
+			ctx,
+			kubetest.RunInput{
+				ClusterProxy:       bootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName),
+				NumberOfNodes:      int(workerMachineCount),
+				ConfigFilePath:     kubetestConfigFilePath,
+				KubeconfigPath:     workloadKubeconfigPath,
+				ArtifactsDirectory: filepath.Join(artifactFolder, "clusters", clusterName, "kubetest"),
+			},
+		)).To(Succeed(), "Failed to run Kubernetes conformance tests")
+	})
+})
@@ -0,0 +1,122 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+)
+
+// KCP remediation spec: breaks a control-plane server out-of-band and asserts the configured MachineHealthCheck
+// triggers KubeadmControlPlane remediation, with a replacement IonosCloudMachine provisioned and joined.
+var _ = Describe("Control-plane remediation using MachineHealthCheck", Label("remediation", "kcp-remediation"), func() {
+	var (
+		specName         = "kcp-remediation"
+		namespace        *corev1.Namespace
+		cancelWatches    context.CancelFunc
+		clusterResources *clusterctl.ApplyClusterTemplateAndWaitResult
+	)
+
+	BeforeEach(func() {
+		Expect(ctx).NotTo(BeNil(), "ctx is required for %s spec", specName)
+		Expect(e2eConfig).NotTo(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(bootstrapClusterProxy).NotTo(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0750)).To(Succeed(), "Invalid argument. Can't create artifactFolder %q for the %s spec", artifactFolder, specName)
+
+		namespace, cancelWatches = setupSpecNamespace(specName)
+		clusterResources = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	AfterEach(func() {
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, clusterResources.Cluster, e2eConfig.GetIntervals, skipCleanup)
+	})
+
+	It("Should remediate a control-plane Machine whose underlying server was powered off", func() {
+		clusterName := generateClusterName(specName)
+
+		By("Creating an HA workload cluster with a MachineHealthCheck for the control plane")
+		clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
+			ClusterProxy: bootstrapClusterProxy,
+			ConfigCluster: clusterctl.ConfigClusterInput{
+				LogFolder:                filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName()),
+				ClusterctlConfigPath:     clusterctlConfigPath,
+				KubeconfigPath:           bootstrapClusterProxy.GetKubeconfigPath(),
+				InfrastructureProvider:   clusterctl.DefaultInfrastructureProvider,
+				Flavor:                   "kcp-remediation",
+				Namespace:                namespace.Name,
+				ClusterName:              clusterName,
+				KubernetesVersion:        e2eConfig.GetVariable(KubernetesVersion),
+				ControlPlaneMachineCount: pointer.Int64(3),
+				WorkerMachineCount:       pointer.Int64(1),
+			},
+			WaitForClusterIntervals:      e2eConfig.GetIntervals(specName, "wait-cluster"),
+			WaitForControlPlaneIntervals: e2eConfig.GetIntervals(specName, "wait-control-plane"),
+			WaitForMachineDeployments:    e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+		}, clusterResources)
+
+		By("Powering off the server backing one of the control-plane Machines")
+		machines := framework.GetControlPlaneMachinesByCluster(ctx, framework.GetControlPlaneMachinesByClusterInput{
+			Lister:      bootstrapClusterProxy.GetClient(),
+			ClusterName: clusterName,
+			Namespace:   namespace.Name,
+		})
+		Expect(machines).NotTo(BeEmpty(), "Expected at least one control-plane Machine")
+		targetMachine := machines[0]
+
+		infraRef := targetMachine.Spec.InfrastructureRef
+		ionosCloudMachine := &infrav1.IonosCloudMachine{}
+		Expect(bootstrapClusterProxy.GetClient().Get(ctx, types.NamespacedName{Namespace: infraRef.Namespace, Name: infraRef.Name}, ionosCloudMachine)).
+			To(Succeed(), "Failed to fetch the IonosCloudMachine backing the target control-plane Machine")
+
+		serverID := ionosCloudMachine.Status.ServerID
+		Expect(serverID).NotTo(BeEmpty(), "The target IonosCloudMachine has no server provisioned yet")
+		cloudEnv.PowerOffServer(ctx, serverID)
+
+		By("Waiting for the MachineHealthCheck to remediate the unhealthy control-plane Machine")
+		mhc := &clusterv1.MachineHealthCheck{}
+		Expect(bootstrapClusterProxy.GetClient().Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: clusterName + "-kcp-mhc"}, mhc)).
+			To(Succeed(), "Failed to fetch the MachineHealthCheck for the control plane")
+		framework.WaitForMachineHealthCheckToRemediateUnhealthyNodeCondition(ctx, framework.WaitForMachineHealthCheckToRemediateUnhealthyNodeConditionInput{
+			ClusterProxy:              bootstrapClusterProxy,
+			Cluster:                   clusterResources.Cluster,
+			MachineHealthCheck:        mhc,
+			WaitForMachineRemediation: e2eConfig.GetIntervals(specName, "remediation"),
+		})
+
+		By("Asserting a replacement control-plane Machine joined the cluster")
+		framework.WaitForControlPlaneAndMachinesReady(ctx, framework.WaitForControlPlaneAndMachinesReadyInput{
+			GetLister:    bootstrapClusterProxy.GetClient(),
+			Cluster:      clusterResources.Cluster,
+			ControlPlane: clusterResources.ControlPlane,
+		}, e2eConfig.GetIntervals(specName, "wait-control-plane")...)
+	})
+})
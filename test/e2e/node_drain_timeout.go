@@ -0,0 +1,186 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+)
+
+// NodeDrainTimeout is the e2e config variable holding the nodeDrainTimeout baked into the node-drain flavor's
+// MachineDeployment (test/e2e/data/infrastructure-ionoscloud/node-drain). The spec uses it to assert the
+// IonosCloudMachine survives for a meaningful portion of the timeout, not just that it is eventually deleted.
+const NodeDrainTimeout = "NODE_DRAIN_TIMEOUT"
+
+// Node drain timeout spec: deletes a Machine that is blocked from draining by a PodDisruptionBudget and asserts
+// the IonosCloudMachine controller waits for spec.template.spec.nodeDrainTimeout before deleting the underlying
+// IONOS Cloud server, and that the server is actually deprovisioned once the timeout elapses.
+var _ = Describe("Node drain timeout", Label("node-drain-timeout"), func() {
+	var (
+		specName         = "node-drain-timeout"
+		namespace        *corev1.Namespace
+		cancelWatches    context.CancelFunc
+		clusterResources *clusterctl.ApplyClusterTemplateAndWaitResult
+	)
+
+	BeforeEach(func() {
+		Expect(ctx).NotTo(BeNil(), "ctx is required for %s spec", specName)
+		Expect(e2eConfig).NotTo(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(e2eConfig.Variables).To(HaveKey(NodeDrainTimeout), "Missing %s variable in the config", NodeDrainTimeout)
+		Expect(bootstrapClusterProxy).NotTo(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0750)).To(Succeed(), "Invalid argument. Can't create artifactFolder %q for the %s spec", artifactFolder, specName)
+
+		namespace, cancelWatches = setupSpecNamespace(specName)
+		clusterResources = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	AfterEach(func() {
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, clusterResources.Cluster, e2eConfig.GetIntervals, skipCleanup)
+	})
+
+	It("Should wait for the drain timeout before deleting the underlying server", func() {
+		clusterName := generateClusterName(specName)
+
+		By("Creating a workload cluster with a nodeDrainTimeout on its worker MachineDeployment")
+		clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
+			ClusterProxy: bootstrapClusterProxy,
+			ConfigCluster: clusterctl.ConfigClusterInput{
+				LogFolder:              filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName()),
+				ClusterctlConfigPath:   clusterctlConfigPath,
+				KubeconfigPath:         bootstrapClusterProxy.GetKubeconfigPath(),
+				InfrastructureProvider: clusterctl.DefaultInfrastructureProvider,
+				Flavor:                 "node-drain",
+				Namespace:              namespace.Name,
+				ClusterName:            clusterName,
+				KubernetesVersion:      e2eConfig.GetVariable(KubernetesVersion),
+			},
+			WaitForClusterIntervals:      e2eConfig.GetIntervals(specName, "wait-cluster"),
+			WaitForControlPlaneIntervals: e2eConfig.GetIntervals(specName, "wait-control-plane"),
+			WaitForMachineDeployments:    e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+		}, clusterResources)
+
+		workloadClusterProxy := bootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName)
+
+		machines := framework.GetMachinesByMachineDeployments(ctx, framework.GetMachinesByMachineDeploymentsInput{
+			Lister:            bootstrapClusterProxy.GetClient(),
+			ClusterName:       clusterName,
+			Namespace:         namespace.Name,
+			MachineDeployment: *clusterResources.MachineDeployments[0],
+		})
+		Expect(machines).NotTo(BeEmpty(), "Expected at least one worker Machine")
+		targetMachine := machines[0]
+
+		By("Deploying an unevictable Pod guarded by a PodDisruptionBudget on the target node")
+		deployUnevictableWorkload(ctx, workloadClusterProxy, targetMachine.Status.NodeRef.Name)
+
+		podDisruptionBudget := &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "unevictable-pdb", Namespace: "default"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				MinAvailable: &intstr.IntOrString{IntVal: 1},
+				Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "unevictable-workload"}},
+			},
+		}
+		Expect(workloadClusterProxy.GetClient().Create(ctx, podDisruptionBudget)).To(Succeed())
+
+		By("Deleting the target Machine")
+		Expect(bootstrapClusterProxy.GetClient().Delete(ctx, &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{
+			Namespace: targetMachine.Namespace,
+			Name:      targetMachine.Name,
+		}})).To(Succeed())
+
+		By("Asserting the Machine reports the drain in progress and the server is not deleted before the drain timeout")
+		machine := &clusterv1.Machine{}
+		Eventually(func() bool {
+			if err := bootstrapClusterProxy.GetClient().Get(ctx, types.NamespacedName{Namespace: targetMachine.Namespace, Name: targetMachine.Name}, machine); err != nil {
+				return false
+			}
+			return conditions.IsFalse(machine, clusterv1.DrainingSucceededCondition)
+		}, e2eConfig.GetIntervals(specName, "wait-machine-remediation")...).Should(BeTrue(),
+			"Expected the Machine to report DrainingSucceeded=False while the drain is blocked")
+
+		drainTimeout, err := time.ParseDuration(e2eConfig.GetVariable(NodeDrainTimeout))
+		Expect(err).NotTo(HaveOccurred(), "Invalid %s variable", NodeDrainTimeout)
+
+		infraRef := targetMachine.Spec.InfrastructureRef
+		ionosCloudMachine := &infrav1.IonosCloudMachine{}
+
+		By("Asserting the IonosCloudMachine is not deleted well before the drain timeout elapses")
+		Consistently(func() error {
+			return bootstrapClusterProxy.GetClient().Get(ctx, types.NamespacedName{Namespace: infraRef.Namespace, Name: infraRef.Name}, ionosCloudMachine)
+		}, drainTimeout/2, 5*time.Second).Should(Succeed(),
+			"Expected the IonosCloudMachine to still exist while the drain timeout has not yet elapsed")
+		serverID := ionosCloudMachine.Status.ServerID
+		Expect(serverID).NotTo(BeEmpty(), "The target IonosCloudMachine has no server provisioned")
+
+		By("Waiting for the drain timeout to elapse and the server to be deprovisioned")
+		Eventually(func() error {
+			return bootstrapClusterProxy.GetClient().Get(ctx, types.NamespacedName{Namespace: infraRef.Namespace, Name: infraRef.Name}, ionosCloudMachine)
+		}, e2eConfig.GetIntervals(specName, "wait-delete-machine")...).ShouldNot(Succeed(),
+			"Expected the IonosCloudMachine to eventually be deleted once the drain timeout elapsed")
+		Eventually(func() bool {
+			_, resp, err := cloudEnv.client.ServersApi.DatacentersServersFindById(ctx, cloudEnv.datacenterID, serverID).Execute()
+			return err != nil && resp != nil && resp.StatusCode == http.StatusNotFound
+		}, e2eConfig.GetIntervals(specName, "wait-delete-machine")...).Should(BeTrue(),
+			"Expected server %q to be deleted from IONOS Cloud", serverID)
+	})
+})
+
+// deployUnevictableWorkload deploys a single-replica Deployment pinned to nodeName, so that together with a
+// PodDisruptionBudget requiring one available Pod it blocks node drain for the duration of the test.
+func deployUnevictableWorkload(ctx context.Context, clusterProxy framework.ClusterProxy, nodeName string) {
+	replicas := int32(1)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "unevictable-workload", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "unevictable-workload"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "unevictable-workload"}},
+				Spec: corev1.PodSpec{
+					NodeName: nodeName,
+					Containers: []corev1.Container{{
+						Name:    "pause",
+						Image:   "registry.k8s.io/pause:3.9",
+						Command: []string{"/pause"},
+					}},
+				},
+			},
+		},
+	}
+	Expect(clusterProxy.GetClient().Create(ctx, deployment)).To(Succeed(), "Failed to deploy the unevictable workload")
+}
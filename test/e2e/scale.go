@@ -0,0 +1,125 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+)
+
+// Scale e2e config variables.
+const (
+	// IonosCloudMode names the IONOS Cloud backend of the e2e environment: "real" or "inmemory". The scale spec
+	// runs only when it is "inmemory" and is skipped otherwise; the spec does not pass the value on to the
+	// manager under test.
+	IonosCloudMode = "IONOS_CLOUD_MODE"
+
+	// ScaleClusterCount is the number of workload clusters to create concurrently.
+	ScaleClusterCount = "SCALE_CLUSTER_COUNT"
+
+	// ScaleMachineCountPerCluster is the number of worker IonosCloudMachines created per workload cluster, on top
+	// of its single control-plane machine.
+	ScaleMachineCountPerCluster = "SCALE_MACHINE_COUNT_PER_CLUSTER"
+)
+
+// Scale spec: exercises reconciliation of a large number of IonosCloudMachine objects. It runs only when
+// IONOS_CLOUD_MODE=inmemory; the manager reconciles against whatever IONOS Cloud client it was started with,
+// since it does not select the in-memory backend of internal/ionoscloud/inmemory.
+var _ = Describe("Scale testing IONOS Cloud provider", Label("scale"), func() {
+	var (
+		specName = "scale"
+	)
+
+	BeforeEach(func() {
+		Expect(ctx).NotTo(BeNil(), "ctx is required for %s spec", specName)
+		Expect(e2eConfig).NotTo(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(e2eConfig.Variables).To(HaveKey(IonosCloudMode), "Missing %s variable in the config", IonosCloudMode)
+		Expect(bootstrapClusterProxy).NotTo(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0750)).To(Succeed(), "Invalid argument. Can't create artifactFolder %q for the %s spec", artifactFolder, specName)
+	})
+
+	It("Should create many workload clusters in parallel and reach Running for all their Machines", func() {
+		if e2eConfig.GetVariable(IonosCloudMode) != "inmemory" {
+			Skip(fmt.Sprintf("Skipping scale test: %s is not set to \"inmemory\"", IonosCloudMode))
+		}
+
+		clusterCount := e2eConfig.GetInt32PtrVariable(ScaleClusterCount)
+		machineCount := e2eConfig.GetInt32PtrVariable(ScaleMachineCountPerCluster)
+
+		By(fmt.Sprintf("Creating %d workload clusters with %d worker machines each", *clusterCount, *machineCount))
+
+		var (
+			wg        sync.WaitGroup
+			startedAt = time.Now()
+		)
+		for i := int32(0); i < *clusterCount; i++ {
+			wg.Add(1)
+			go func(i int32) {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				clusterName := fmt.Sprintf("%s-%d", generateClusterName(specName), i)
+				namespace, cancelWatches := setupSpecNamespace(fmt.Sprintf("%s-%d", specName, i))
+				clusterResources := new(clusterctl.ApplyClusterTemplateAndWaitResult)
+				defer func() {
+					dumpSpecResourcesAndCleanup(ctx, fmt.Sprintf("%s-%d", specName, i), bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, clusterResources.Cluster, e2eConfig.GetIntervals, skipCleanup)
+				}()
+
+				clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
+					ClusterProxy: bootstrapClusterProxy,
+					ConfigCluster: clusterctl.ConfigClusterInput{
+						LogFolder:                filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName()),
+						ClusterctlConfigPath:     clusterctlConfigPath,
+						KubeconfigPath:           bootstrapClusterProxy.GetKubeconfigPath(),
+						InfrastructureProvider:   clusterctl.DefaultInfrastructureProvider,
+						Flavor:                   "scale",
+						Namespace:                namespace.Name,
+						ClusterName:              clusterName,
+						KubernetesVersion:        e2eConfig.GetVariable(KubernetesVersion),
+						ControlPlaneMachineCount: pointer.Int64(1),
+						WorkerMachineCount:       pointer.Int64(int64(*machineCount)),
+					},
+					WaitForClusterIntervals:      e2eConfig.GetIntervals(specName, "wait-cluster"),
+					WaitForControlPlaneIntervals: e2eConfig.GetIntervals(specName, "wait-control-plane"),
+					WaitForMachineDeployments:    e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+				}, clusterResources)
+
+				framework.WaitForMachineDeploymentNodesToExist(ctx, framework.WaitForMachineDeploymentNodesToExistInput{
+					Lister:            bootstrapClusterProxy.GetClient(),
+					Cluster:           clusterResources.Cluster,
+					MachineDeployment: clusterResources.MachineDeployments[0],
+				}, e2eConfig.GetIntervals(specName, "wait-worker-nodes")...)
+			}(i)
+		}
+		wg.Wait()
+
+		By(fmt.Sprintf("Reconciled %d clusters / %d worker machines in %s", *clusterCount, (*clusterCount)*(*machineCount), time.Since(startedAt)))
+	})
+})
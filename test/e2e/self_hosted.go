@@ -0,0 +1,169 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+)
+
+// Self-hosted (pivot) spec: creates a workload cluster on the bootstrap kind cluster, moves management of that
+// cluster onto itself with clusterctl.Move, and asserts the now self-hosted cluster keeps reconciling. The
+// cluster is moved back onto the bootstrap cluster before the spec ends, since bootstrapClusterProxy and the
+// underlying kind cluster are shared suite-global state that every other concurrently-running spec, and this
+// spec's own AfterEach, depend on.
+var _ = Describe("Self-hosted IONOS Cloud provider", Label("self-hosted"), func() {
+	var (
+		specName             = "self-hosted"
+		namespace            *corev1.Namespace
+		cancelWatches        context.CancelFunc
+		clusterResources     *clusterctl.ApplyClusterTemplateAndWaitResult
+		selfHostedProxy      framework.ClusterProxy
+		selfHostedCancelFunc context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		Expect(ctx).NotTo(BeNil(), "ctx is required for %s spec", specName)
+		Expect(e2eConfig).NotTo(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(bootstrapClusterProxy).NotTo(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0750)).To(Succeed(), "Invalid argument. Can't create artifactFolder %q for the %s spec", artifactFolder, specName)
+
+		namespace, cancelWatches = setupSpecNamespace(specName)
+		clusterResources = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	AfterEach(func() {
+		if selfHostedProxy != nil {
+			selfHostedProxy.Dispose(ctx)
+		}
+		if selfHostedCancelFunc != nil {
+			selfHostedCancelFunc()
+		}
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, clusterResources.Cluster, e2eConfig.GetIntervals, skipCleanup)
+	})
+
+	It("Should pivot the management cluster onto the workload cluster it created", func() {
+		clusterName := generateClusterName(specName)
+
+		By("Creating a workload cluster on the bootstrap cluster")
+		clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
+			ClusterProxy: bootstrapClusterProxy,
+			ConfigCluster: clusterctl.ConfigClusterInput{
+				LogFolder:              filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName()),
+				ClusterctlConfigPath:   clusterctlConfigPath,
+				KubeconfigPath:         bootstrapClusterProxy.GetKubeconfigPath(),
+				InfrastructureProvider: clusterctl.DefaultInfrastructureProvider,
+				Flavor:                 "self-hosted",
+				Namespace:              namespace.Name,
+				ClusterName:            clusterName,
+				KubernetesVersion:      e2eConfig.GetVariable(KubernetesVersion),
+			},
+			WaitForClusterIntervals:      e2eConfig.GetIntervals(specName, "wait-cluster"),
+			WaitForControlPlaneIntervals: e2eConfig.GetIntervals(specName, "wait-control-plane"),
+			WaitForMachineDeployments:    e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+		}, clusterResources)
+
+		By("Getting a proxy for the workload cluster")
+		selfHostedProxy = bootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName)
+
+		By("Copying the IONOS credentials Secret onto the workload cluster ahead of the pivot")
+		cloudEnv.copyCredentialsSecret(ctx, bootstrapClusterProxy, selfHostedProxy, namespace.Name)
+
+		By("Initializing the self-hosted cluster with the IONOS Cloud provider")
+		var selfHostedWatchesCtx context.Context
+		selfHostedWatchesCtx, selfHostedCancelFunc = context.WithCancel(ctx)
+		clusterctl.InitManagementClusterAndWatchControllerLogs(selfHostedWatchesCtx, clusterctl.InitManagementClusterAndWatchControllerLogsInput{
+			ClusterProxy:            selfHostedProxy,
+			ClusterctlConfigPath:    clusterctlConfigPath,
+			InfrastructureProviders: e2eConfig.InfrastructureProviders(),
+			LogFolder:               filepath.Join(artifactFolder, "clusters", clusterName),
+		}, e2eConfig.GetIntervals(specName, "wait-controllers")...)
+
+		By("Moving the cluster to be self-hosted")
+		clusterctl.Move(ctx, clusterctl.MoveInput{
+			LogFolder:            filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName()),
+			ClusterctlConfigPath: clusterctlConfigPath,
+			FromKubeconfigPath:   bootstrapClusterProxy.GetKubeconfigPath(),
+			ToKubeconfigPath:     selfHostedProxy.GetKubeconfigPath(),
+			Namespace:            namespace.Name,
+		})
+
+		By("Asserting the self-hosted cluster keeps reconciling by scaling its MachineDeployment up and down")
+		md := clusterResources.MachineDeployments[0]
+		originalReplicas := *md.Spec.Replicas
+		framework.ScaleAndWaitMachineDeployment(ctx, framework.ScaleAndWaitMachineDeploymentInput{
+			ClusterProxy:              selfHostedProxy,
+			Cluster:                   clusterResources.Cluster,
+			MachineDeployment:         md,
+			Replicas:                  originalReplicas + 1,
+			WaitForMachineDeployments: e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+		})
+		framework.ScaleAndWaitMachineDeployment(ctx, framework.ScaleAndWaitMachineDeploymentInput{
+			ClusterProxy:              selfHostedProxy,
+			Cluster:                   clusterResources.Cluster,
+			MachineDeployment:         md,
+			Replicas:                  originalReplicas,
+			WaitForMachineDeployments: e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+		})
+
+		By("Collecting logs from the self-hosted management cluster")
+		dumpClusterProxyMachineLogs(ctx, selfHostedProxy, clusterResources.Cluster, filepath.Join(artifactFolder, "clusters", clusterName+"-self-hosted"))
+
+		By("Moving the cluster back onto the bootstrap cluster")
+		clusterctl.Move(ctx, clusterctl.MoveInput{
+			LogFolder:            filepath.Join(artifactFolder, "clusters", clusterName),
+			ClusterctlConfigPath: clusterctlConfigPath,
+			FromKubeconfigPath:   selfHostedProxy.GetKubeconfigPath(),
+			ToKubeconfigPath:     bootstrapClusterProxy.GetKubeconfigPath(),
+			Namespace:            namespace.Name,
+		})
+	})
+})
+
+// dumpClusterProxyMachineLogs collects controller/node logs for every Machine in cluster from proxy's log
+// collector into logPath, mirroring dumpBootstrapClusterLogs (suite_test.go) for a cluster other than the
+// shared bootstrap one.
+func dumpClusterProxyMachineLogs(ctx context.Context, proxy framework.ClusterProxy, cluster *clusterv1.Cluster, logPath string) {
+	collector := proxy.GetLogCollector()
+	if collector == nil {
+		return
+	}
+
+	machines := framework.GetMachinesByCluster(ctx, framework.GetMachinesByClusterInput{
+		Lister:      proxy.GetClient(),
+		ClusterName: cluster.Name,
+		Namespace:   cluster.Namespace,
+	})
+	for i := range machines {
+		machine := machines[i]
+		if err := collector.CollectMachineLog(ctx, proxy.GetClient(), &machine, filepath.Join(logPath, "machines", machine.Name)); err != nil {
+			fmt.Printf("Failed to get logs for self-hosted machine %s: %v\n", machine.Name, err)
+		}
+	}
+}